@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFileMode and defaultDirMode restrict generated mock data to the
+// owner only. mockgen exists to exercise encryption code against
+// sensitive-looking data, so the corpus itself shouldn't be world-readable
+// by default (the same posture Vault's debug bundle and NNCP's spool take).
+const (
+	defaultFileMode fs.FileMode = 0600
+	defaultDirMode  fs.FileMode = 0700
+)
+
+// defaultMaxTotalFiles caps a profile's output when MaxTotalFiles is left
+// unset, so a typo'd depth/fanout combination can't blow up into millions
+// of files before anyone notices.
+const defaultMaxTotalFiles = 50_000
+
+// Config describes a single named corpus profile: how many files of each
+// kind to generate, how deep/wide the subdirectory tree should be, and the
+// gofakeit seed to use so the profile is byte-for-byte reproducible.
+type Config struct {
+	Name       string      `yaml:"name"`
+	BaseDir    string      `yaml:"base_dir"`
+	TextFiles  int         `yaml:"text_files"`
+	CsvFiles   int         `yaml:"csv_files"`
+	ImageFiles int         `yaml:"image_files"`
+	SubDirs    int         `yaml:"sub_dirs"`
+	Seed       int64       `yaml:"seed"`
+	FileMode   fs.FileMode `yaml:"file_mode"`
+	DirMode    fs.FileMode `yaml:"dir_mode"`
+
+	// MaxDepth bounds how many levels generateInto recurses below BaseDir.
+	// 0 (the original behavior) means no subdirectories at all; SubDirs is
+	// still honored as the level-1 fanout when FanoutPerLevel is empty.
+	MaxDepth int `yaml:"max_depth"`
+	// FanoutPerLevel[i] is the number of subdirectories created inside each
+	// directory at depth i+1. A short slice repeats its last element for
+	// any deeper level; an empty slice falls back to SubDirs at level 1 and
+	// zero fanout below that.
+	FanoutPerLevel []int `yaml:"fanout_per_level"`
+	// LevelFileMultiplier[i] scales TextFiles/CsvFiles/ImageFiles for every
+	// directory created at depth i+1. A short slice repeats its last
+	// element; an empty slice holds file counts constant at every depth.
+	LevelFileMultiplier []float64 `yaml:"level_file_multiplier"`
+	// MaxTotalFiles stops generation once this many files have been
+	// written, regardless of depth/fanout. 0 uses defaultMaxTotalFiles.
+	MaxTotalFiles int `yaml:"max_total_files"`
+
+	// ImageFormats is a weighted distribution over the image extensions
+	// mockgen should produce (see pickImageExt in images.go). An empty
+	// slice keeps the original all-JPEG behavior.
+	ImageFormats []ImageFormatWeight `yaml:"image_formats"`
+
+	// Concurrency bounds the file-writing worker pool (see pool.go). 0
+	// uses defaultConcurrency.
+	Concurrency int `yaml:"concurrency"`
+
+	// EdgeCases augments the corpus with boundary-condition files (see
+	// edgecases.go) when enabled.
+	EdgeCases EdgeCases `yaml:"edge_cases"`
+
+	// DryRun prints the tree mockgen would produce without writing
+	// anything. It's a run-time toggle (set via -dry-run), not something
+	// profiles declare in YAML.
+	DryRun bool `yaml:"-"`
+}
+
+// applyModeDefaults fills in FileMode/DirMode when a profile leaves them
+// unset (the YAML zero value). Called by GenerateProfile, so -permissive in
+// main sets explicit non-zero modes before that point to opt back into the
+// legacy world-readable permissions.
+func (cfg Config) applyModeDefaults() Config {
+	if cfg.FileMode == 0 {
+		cfg.FileMode = defaultFileMode
+	}
+	if cfg.DirMode == 0 {
+		cfg.DirMode = defaultDirMode
+	}
+	if cfg.MaxTotalFiles == 0 {
+		cfg.MaxTotalFiles = defaultMaxTotalFiles
+	}
+	if cfg.MaxDepth == 0 {
+		switch {
+		case len(cfg.FanoutPerLevel) > 0:
+			cfg.MaxDepth = len(cfg.FanoutPerLevel)
+		case cfg.SubDirs > 0:
+			// Preserve the original one-level-of-subdirectories behavior
+			// for profiles that only set SubDirs.
+			cfg.MaxDepth = 1
+		}
+	}
+	return cfg
+}
+
+// fanoutAt returns how many subdirectories to create at depth level+1 (i.e.
+// how many children a directory at depth `level` should get). Level 0 is
+// BaseDir itself, so fanoutAt(cfg, 0) is the fanout of the first ring of
+// subdirectories.
+func (cfg Config) fanoutAt(level int) int {
+	if len(cfg.FanoutPerLevel) == 0 {
+		if level == 0 {
+			return cfg.SubDirs
+		}
+		return 0
+	}
+	if level < len(cfg.FanoutPerLevel) {
+		return cfg.FanoutPerLevel[level]
+	}
+	return cfg.FanoutPerLevel[len(cfg.FanoutPerLevel)-1]
+}
+
+// fileMultiplierAt returns the file-count multiplier for directories at
+// depth level, repeating the last configured multiplier for deeper levels.
+func (cfg Config) fileMultiplierAt(level int) float64 {
+	if level == 0 || len(cfg.LevelFileMultiplier) == 0 {
+		return 1
+	}
+	idx := level - 1
+	if idx < len(cfg.LevelFileMultiplier) {
+		return cfg.LevelFileMultiplier[idx]
+	}
+	return cfg.LevelFileMultiplier[len(cfg.LevelFileMultiplier)-1]
+}
+
+// manifestFile is the on-disk shape of the YAML file passed via -manifest:
+// a flat list of named profiles.
+type manifestFile struct {
+	Profiles []Config `yaml:"profiles"`
+}
+
+// LoadConfig reads a YAML manifest of corpus profiles from path and returns
+// them in file order. Callers typically look up a single profile by Name
+// (see -profile in main), but the full slice is returned so tests can
+// generate every profile in one pass.
+func LoadConfig(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %s: %w", path, err)
+	}
+
+	var mf manifestFile
+	if err := yaml.Unmarshal(data, &mf); err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+
+	if len(mf.Profiles) == 0 {
+		return nil, fmt.Errorf("manifest %s defines no profiles", path)
+	}
+
+	return mf.Profiles, nil
+}
+
+// findProfile returns the profile named name, or an error listing the
+// profiles that were actually available.
+func findProfile(profiles []Config, name string) (Config, error) {
+	for _, cfg := range profiles {
+		if cfg.Name == name {
+			return cfg, nil
+		}
+	}
+
+	known := make([]string, 0, len(profiles))
+	for _, cfg := range profiles {
+		known = append(known, cfg.Name)
+	}
+	return Config{}, fmt.Errorf("no profile named %q (known profiles: %v)", name, known)
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// EdgeCases toggles generation of boundary-condition files alongside a
+// profile's normal corpus. Real encryption code breaks at the edges, not
+// on the happy path, so this adds: zero/one-byte files, common block-size
+// boundaries, a sparse multi-gigabyte file, unusual names (spaces, emoji,
+// RTL, near NAME_MAX), unusual modes (executable, setuid), and — on
+// non-Windows — symlinks in every combination of relative/absolute and
+// inside/outside BaseDir.
+type EdgeCases struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// edgeCasesDirName is where generateEdgeCases writes, kept separate from
+// the rest of the corpus so tests can target it (or skip it) as a unit.
+const edgeCasesDirName = "edge_cases"
+
+// sparseFileSize is the logical size written via Truncate for the sparse
+// edge case; only a handful of bytes are actually written before it.
+const sparseFileSize = 1 << 30 // 1 GiB
+
+// generateEdgeCases writes the boundary-condition corpus under
+// cfg.BaseDir/edge_cases and returns one ManifestEntry per file, each
+// tagged with a Kind so tests can select edge cases individually. Unlike
+// the main corpus, these files are few, fixed, and mostly not generated
+// from faked content, so they're written directly rather than through the
+// fileJob worker pool.
+func generateEdgeCases(cfg Config) ([]ManifestEntry, error) {
+	dir := filepath.Join(cfg.BaseDir, edgeCasesDirName)
+	if err := os.MkdirAll(dir, cfg.DirMode); err != nil {
+		return nil, err
+	}
+
+	var entries []ManifestEntry
+
+	sized, err := generateSizedEdgeCases(cfg, dir)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, sized...)
+
+	sparseEntry, err := generateSparseEdgeCase(cfg, dir)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, sparseEntry)
+
+	named, err := generateUnusualNameEdgeCases(cfg, dir)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, named...)
+
+	if runtime.GOOS != "windows" {
+		moded, err := generateUnusualModeEdgeCases(cfg, dir)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, moded...)
+
+		symlinked, err := generateSymlinkEdgeCases(cfg, dir)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, symlinked...)
+	}
+
+	return entries, nil
+}
+
+// generateSizedEdgeCases writes a zero-byte file, a one-byte file, and
+// files landing exactly on common block-size boundaries.
+func generateSizedEdgeCases(cfg Config, dir string) ([]ManifestEntry, error) {
+	cases := []struct {
+		name string
+		kind string
+		size int
+	}{
+		{"zero_byte.bin", "zero-byte", 0},
+		{"one_byte.bin", "one-byte", 1},
+		{"boundary_16.bin", "block-boundary", 16},
+		{"boundary_4096.bin", "block-boundary", 4096},
+		{"boundary_65536.bin", "block-boundary", 65536},
+	}
+
+	entries := make([]ManifestEntry, 0, len(cases))
+	for _, c := range cases {
+		path := filepath.Join(dir, c.name)
+		size, hash, err := writeHashed(path, cfg.FileMode, func(w io.Writer) error {
+			_, err := w.Write(bytes.Repeat([]byte{0xAA}, c.size))
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("edge case %s: %w", c.name, err)
+		}
+		entries = append(entries, ManifestEntry{
+			Path: relToBaseDir(cfg, path),
+			Kind: c.kind,
+			MIME: "application/octet-stream",
+			Size: size,
+			Mode: cfg.FileMode,
+			Hash: hash,
+		})
+	}
+	return entries, nil
+}
+
+// generateSparseEdgeCase writes a handful of bytes then truncates the file
+// up to sparseFileSize, producing a sparse file on filesystems that
+// support holes (ext4, APFS, ...) without actually allocating a gigabyte.
+// The hash is left blank: hashing a sparse file forces the OS to
+// materialize every hole, which defeats the point of using one.
+func generateSparseEdgeCase(cfg Config, dir string) (ManifestEntry, error) {
+	path := filepath.Join(dir, "sparse_1gib.bin")
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, cfg.FileMode)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString("sparse file marker"); err != nil {
+		return ManifestEntry{}, err
+	}
+	if err := file.Truncate(sparseFileSize); err != nil {
+		return ManifestEntry{}, err
+	}
+
+	return ManifestEntry{
+		Path: relToBaseDir(cfg, path),
+		Kind: "sparse",
+		MIME: "application/octet-stream",
+		Size: sparseFileSize,
+		Mode: cfg.FileMode,
+	}, nil
+}
+
+// generateUnusualNameEdgeCases writes otherwise-ordinary files under names
+// that tend to trip up path handling: spaces, emoji, RTL script, and a
+// name close to (but comfortably under) the common 255-byte NAME_MAX.
+func generateUnusualNameEdgeCases(cfg Config, dir string) ([]ManifestEntry, error) {
+	names := []string{
+		"name with spaces.txt",
+		"emoji_🎉📂🔥.txt",
+		"rtl_اختبار_עברית.txt",
+		strings.Repeat("a", 200) + "_near_name_max.txt",
+	}
+
+	entries := make([]ManifestEntry, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		size, hash, err := writeHashed(path, cfg.FileMode, func(w io.Writer) error {
+			_, err := io.WriteString(w, "edge case content")
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("edge case unicode name %q: %w", name, err)
+		}
+		entries = append(entries, ManifestEntry{
+			Path: relToBaseDir(cfg, path),
+			Kind: "unicode-name",
+			MIME: "text/plain",
+			Size: size,
+			Mode: cfg.FileMode,
+			Hash: hash,
+		})
+	}
+	return entries, nil
+}
+
+// generateUnusualModeEdgeCases writes a file executable by its owner and
+// one with the setuid bit set, both of which encryption code that
+// preserves permissions needs to round-trip correctly.
+func generateUnusualModeEdgeCases(cfg Config, dir string) ([]ManifestEntry, error) {
+	execPath := filepath.Join(dir, "executable.sh")
+	execMode := os.FileMode(0700)
+	size, hash, err := writeHashed(execPath, execMode, func(w io.Writer) error {
+		_, err := io.WriteString(w, "#!/bin/sh\necho edge case\n")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("edge case executable mode: %w", err)
+	}
+	execEntry := ManifestEntry{
+		Path: relToBaseDir(cfg, execPath),
+		Kind: "executable-mode",
+		MIME: "text/x-shellscript",
+		Size: size,
+		Mode: execMode,
+		Hash: hash,
+	}
+
+	setuidPath := filepath.Join(dir, "setuid_bin")
+	setuidMode := cfg.FileMode | os.ModeSetuid
+	size, hash, err = writeHashed(setuidPath, setuidMode, func(w io.Writer) error {
+		_, err := io.WriteString(w, "edge case content")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("edge case setuid mode: %w", err)
+	}
+	setuidEntry := ManifestEntry{
+		Path: relToBaseDir(cfg, setuidPath),
+		Kind: "setuid-mode",
+		MIME: "application/octet-stream",
+		Size: size,
+		Mode: setuidMode,
+		Hash: hash,
+	}
+
+	return []ManifestEntry{execEntry, setuidEntry}, nil
+}
+
+// generateSymlinkEdgeCases creates every combination of relative/absolute
+// and inside/outside-BaseDir symlinks. The "outside" target lives in the
+// OS temp directory at a name derived from cfg.BaseDir, so repeated runs
+// against the same BaseDir overwrite one file instead of leaking a fresh
+// one into the OS temp directory every time mockgen regenerates a corpus.
+func generateSymlinkEdgeCases(cfg Config, dir string) ([]ManifestEntry, error) {
+	internalTarget := filepath.Join(dir, "zero_byte.bin")
+
+	externalPath := externalSymlinkTargetPath(cfg.BaseDir)
+	external, err := os.OpenFile(externalPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("edge case external symlink target: %w", err)
+	}
+	_, writeErr := external.WriteString("outside BaseDir")
+	closeErr := external.Close()
+	if writeErr != nil {
+		return nil, fmt.Errorf("edge case external symlink target: %w", writeErr)
+	}
+	if closeErr != nil {
+		return nil, fmt.Errorf("edge case external symlink target: %w", closeErr)
+	}
+
+	relInternal, err := filepath.Rel(dir, internalTarget)
+	if err != nil {
+		return nil, err
+	}
+	relExternal, err := filepath.Rel(dir, externalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cases := []struct {
+		name   string
+		target string
+		kind   string
+	}{
+		{"symlink_relative_internal", relInternal, "symlink-relative-internal"},
+		{"symlink_absolute_internal", internalTarget, "symlink-absolute-internal"},
+		{"symlink_relative_external", relExternal, "symlink-relative-external"},
+		{"symlink_absolute_external", externalPath, "symlink-absolute-external"},
+	}
+
+	entries := make([]ManifestEntry, 0, len(cases))
+	for _, c := range cases {
+		linkPath := filepath.Join(dir, c.name)
+		if err := os.Symlink(c.target, linkPath); err != nil {
+			return nil, fmt.Errorf("edge case %s: %w", c.name, err)
+		}
+		entries = append(entries, ManifestEntry{
+			Path:   relToBaseDir(cfg, linkPath),
+			Kind:   c.kind,
+			MIME:   "inode/symlink",
+			Target: c.target,
+		})
+	}
+	return entries, nil
+}
+
+// externalSymlinkTargetPath returns a deterministic OS-temp-dir path for
+// the "outside BaseDir" symlink target, keyed on baseDir so repeated runs
+// against the same profile overwrite one file instead of leaking a fresh
+// one into the OS temp directory every time.
+func externalSymlinkTargetPath(baseDir string) string {
+	sum := sha256.Sum256([]byte(baseDir))
+	name := "mockgen-edge-external-" + hex.EncodeToString(sum[:8]) + ".bin"
+	return filepath.Join(os.TempDir(), name)
+}
+
+// relToBaseDir expresses path relative to cfg.BaseDir, forward-slash
+// separated, matching how the main corpus's manifest entries are written.
+func relToBaseDir(cfg Config, path string) string {
+	rel, err := filepath.Rel(cfg.BaseDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestGenerateProfileEdgeCases verifies that enabling EdgeCases produces
+// the boundary-condition files and that each lands in the manifest with a
+// distinguishing Kind.
+func TestGenerateProfileEdgeCases(t *testing.T) {
+	cfg := Config{
+		Name:      "test-edge-cases",
+		BaseDir:   t.TempDir(),
+		TextFiles: 1,
+		Seed:      13,
+		EdgeCases: EdgeCases{Enabled: true},
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	manifest, err := LoadManifest(cfg.BaseDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+
+	kinds := make(map[string]bool)
+	for _, e := range manifest.Entries {
+		kinds[e.Kind] = true
+	}
+
+	want := []string{"zero-byte", "one-byte", "block-boundary", "sparse", "unicode-name"}
+	if runtime.GOOS != "windows" {
+		want = append(want, "executable-mode", "setuid-mode", "symlink-relative-internal", "symlink-absolute-external")
+	}
+	for _, k := range want {
+		if !kinds[k] {
+			t.Errorf("manifest is missing an entry with Kind %q", k)
+		}
+	}
+
+	zeroByte := filepath.Join(cfg.BaseDir, edgeCasesDirName, "zero_byte.bin")
+	info, err := os.Stat(zeroByte)
+	if err != nil {
+		t.Fatalf("stat zero-byte edge case: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("zero_byte.bin size = %d, want 0", info.Size())
+	}
+
+	if runtime.GOOS != "windows" {
+		setuidBin := filepath.Join(cfg.BaseDir, edgeCasesDirName, "setuid_bin")
+		info, err := os.Stat(setuidBin)
+		if err != nil {
+			t.Fatalf("stat setuid edge case: %v", err)
+		}
+		if info.Mode()&os.ModeSetuid == 0 {
+			t.Errorf("setuid_bin mode = %v, want ModeSetuid set", info.Mode())
+		}
+	}
+}
+
+// TestGenerateProfileWithoutEdgeCases verifies the feature is opt-in: a
+// profile that leaves EdgeCases unset gets none of these files.
+func TestGenerateProfileWithoutEdgeCases(t *testing.T) {
+	cfg := Config{
+		Name:      "test-no-edge-cases",
+		BaseDir:   t.TempDir(),
+		TextFiles: 1,
+		Seed:      13,
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.BaseDir, edgeCasesDirName)); !os.IsNotExist(err) {
+		t.Errorf("expected no %s directory, stat err = %v", edgeCasesDirName, err)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// GenerateProfile creates the on-disk corpus described by cfg. It is the
+// library entry point: encryption test suites can call it directly instead
+// of shelling out to the mockgen binary.
+func GenerateProfile(cfg Config) error {
+	return generateMockData(cfg.applyModeDefaults())
+}
+
+func generateMockData(cfg Config) error {
+	if !cfg.DryRun {
+		// Create the directory
+		// Note: ensure this happens first
+		// Note: had to add removal first to prevent error
+		if err := os.RemoveAll(cfg.BaseDir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(cfg.BaseDir, cfg.DirMode); err != nil {
+			return err
+		}
+	}
+
+	// treeFaker drives every construction-time decision (which image
+	// format a file gets, directory layout) as the tree is walked. It runs
+	// single-threaded here, before any worker-pool fan-out, so the layout
+	// stays deterministic under a fixed seed regardless of concurrency.
+	treeFaker := gofakeit.New(uint64(cfg.Seed))
+
+	var jobs []fileJob
+	if err := generateInto(cfg.BaseDir, 0, cfg, treeFaker, &jobs); err != nil {
+		return err
+	}
+
+	if cfg.DryRun {
+		for _, job := range jobs {
+			fmt.Printf("%s (%s)\n", job.path, job.kind)
+		}
+		if cfg.EdgeCases.Enabled {
+			fmt.Println("(edge cases are not previewed in dry-run mode)")
+		}
+		fmt.Printf("--- Dry run: would generate %d files under '%s' ---\n", len(jobs), cfg.BaseDir)
+		return nil
+	}
+
+	entries, err := runJobs(jobs, cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.EdgeCases.Enabled {
+		edgeEntries, err := generateEdgeCases(cfg)
+		if err != nil {
+			return fmt.Errorf("generating edge cases: %w", err)
+		}
+		entries = append(entries, edgeEntries...)
+	}
+
+	if err := writeManifest(cfg.BaseDir, entries, cfg.FileMode); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	fmt.Printf("--- Generated %d files under '%s' (manifest: %s) ---\n", len(entries), cfg.BaseDir, manifestFileName)
+	return nil
+}
+
+// generateInto walks the directory tree below dir (creating real
+// directories unless cfg.DryRun), appending a fileJob for every file a
+// directory at the given depth should hold, then recursing into
+// fanoutAt(level) child directories until MaxDepth is reached or
+// MaxTotalFiles stops generation early. Each job is assigned a
+// deterministic per-file seed, derived from cfg.Seed and the job's
+// position, so the corpus comes out byte-identical no matter how the
+// worker pool in pool.go schedules the actual writes.
+func generateInto(dir string, level int, cfg Config, treeFaker *gofakeit.Faker, jobs *[]fileJob) error {
+	mult := cfg.fileMultiplierAt(level)
+	textFiles := scale(cfg.TextFiles, mult)
+	csvFiles := scale(cfg.CsvFiles, mult)
+	imageFiles := scale(cfg.ImageFiles, mult)
+
+	queue := func(path string, kind fileKind) bool {
+		if len(*jobs) >= cfg.MaxTotalFiles {
+			return false
+		}
+		seed := cfg.Seed + int64(len(*jobs)) + 1
+		*jobs = append(*jobs, fileJob{path: path, kind: kind, seed: seed})
+		return true
+	}
+
+	for i := 0; i < textFiles; i++ {
+		if !queue(filepath.Join(dir, fmt.Sprintf("document_%d.txt", i)), kindText) {
+			return nil
+		}
+	}
+	for i := 0; i < csvFiles; i++ {
+		if !queue(filepath.Join(dir, fmt.Sprintf("transactions_%d.csv", i)), kindCSV) {
+			return nil
+		}
+	}
+	for i := 0; i < imageFiles; i++ {
+		ext := pickImageExt(treeFaker, cfg)
+		if !queue(filepath.Join(dir, fmt.Sprintf("photo_%d%s", i, ext)), kindImage) {
+			return nil
+		}
+	}
+
+	if level >= cfg.MaxDepth {
+		return nil
+	}
+
+	fanout := cfg.fanoutAt(level)
+	for i := 0; i < fanout; i++ {
+		if len(*jobs) >= cfg.MaxTotalFiles {
+			return nil
+		}
+		subDir := filepath.Join(dir, fmt.Sprintf("level%d_dir%d", level+1, i))
+		if !cfg.DryRun {
+			if err := os.MkdirAll(subDir, cfg.DirMode); err != nil {
+				return err
+			}
+		}
+		if err := generateInto(subDir, level+1, cfg, treeFaker, jobs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scale applies a per-level file-count multiplier, rounding down and never
+// going negative.
+func scale(count int, mult float64) int {
+	scaled := int(float64(count) * mult)
+	if scaled < 0 {
+		return 0
+	}
+	return scaled
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestGenerateProfileDefaultModes verifies that generated files and
+// directories land on the restrictive owner-only defaults, not the old
+// world-readable 0644/0755.
+func TestGenerateProfileDefaultModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX permission bits aren't meaningful on windows")
+	}
+
+	cfg := Config{
+		Name:       "test",
+		BaseDir:    t.TempDir(),
+		TextFiles:  1,
+		CsvFiles:   1,
+		ImageFiles: 1,
+		SubDirs:    1,
+		Seed:       42,
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	dirInfo, err := os.Stat(cfg.BaseDir)
+	if err != nil {
+		t.Fatalf("stat base dir: %v", err)
+	}
+	if got := dirInfo.Mode().Perm(); got != defaultDirMode {
+		t.Errorf("base dir mode = %o, want %o", got, defaultDirMode)
+	}
+
+	fileInfo, err := os.Stat(filepath.Join(cfg.BaseDir, "document_0.txt"))
+	if err != nil {
+		t.Fatalf("stat text file: %v", err)
+	}
+	if got := fileInfo.Mode().Perm(); got != defaultFileMode {
+		t.Errorf("text file mode = %o, want %o", got, defaultFileMode)
+	}
+}
+
+// TestGenerateProfilePermissiveModes verifies that explicitly requesting the
+// legacy world-readable modes (what -permissive sets) is still honored.
+func TestGenerateProfilePermissiveModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX permission bits aren't meaningful on windows")
+	}
+
+	cfg := Config{
+		Name:      "test-permissive",
+		BaseDir:   t.TempDir(),
+		TextFiles: 1,
+		Seed:      42,
+		FileMode:  0644,
+		DirMode:   0755,
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	fileInfo, err := os.Stat(filepath.Join(cfg.BaseDir, "document_0.txt"))
+	if err != nil {
+		t.Fatalf("stat text file: %v", err)
+	}
+	if got := fileInfo.Mode().Perm(); got != 0644 {
+		t.Errorf("text file mode = %o, want 0644", got)
+	}
+}
+
+// TestGenerateProfileRecursesToMaxDepth verifies that FanoutPerLevel is
+// honored at every level down to MaxDepth, not just the first ring of
+// subdirectories.
+func TestGenerateProfileRecursesToMaxDepth(t *testing.T) {
+	cfg := Config{
+		Name:           "test-deep",
+		BaseDir:        t.TempDir(),
+		TextFiles:      1,
+		Seed:           42,
+		MaxDepth:       2,
+		FanoutPerLevel: []int{2, 2},
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	depth2 := filepath.Join(cfg.BaseDir, "level1_dir0", "level2_dir0", "document_0.txt")
+	if _, err := os.Stat(depth2); err != nil {
+		t.Errorf("expected file at depth 2: %v", err)
+	}
+}
+
+// TestGenerateProfileDryRunWritesNothing verifies that DryRun reports a
+// tree without touching the filesystem.
+func TestGenerateProfileDryRunWritesNothing(t *testing.T) {
+	cfg := Config{
+		Name:      "test-dry-run",
+		BaseDir:   filepath.Join(t.TempDir(), "unwritten"),
+		TextFiles: 3,
+		Seed:      42,
+		DryRun:    true,
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	if _, err := os.Stat(cfg.BaseDir); !os.IsNotExist(err) {
+		t.Errorf("expected BaseDir to not exist after a dry run, stat err = %v", err)
+	}
+}
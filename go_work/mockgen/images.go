@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/chai2010/webp"
+	"golang.org/x/image/tiff"
+)
+
+// ImageEncoder writes an image.Image out in one on-disk format. Registered
+// implementations are looked up by the extension mockgen was asked to
+// produce, so adding a format never touches the call site in writeImageFile.
+type ImageEncoder interface {
+	Encode(w io.Writer, img image.Image) error
+	Ext() string
+	MIME() string
+}
+
+// ImageFormatWeight is one entry in a profile's weighted image format
+// distribution: Ext (e.g. ".webp") is generated with probability
+// proportional to Weight relative to the other entries.
+type ImageFormatWeight struct {
+	Ext    string  `yaml:"ext"`
+	Weight float64 `yaml:"weight"`
+}
+
+// imageEncoders maps a file extension (including the leading dot) to the
+// encoder that produces it.
+var imageEncoders = map[string]ImageEncoder{}
+
+func registerImageEncoder(enc ImageEncoder) {
+	imageEncoders[enc.Ext()] = enc
+}
+
+func init() {
+	registerImageEncoder(pngEncoder{})
+	registerImageEncoder(jpegEncoder{Quality: 85})
+	registerImageEncoder(gifEncoder{})
+	registerImageEncoder(webpEncoder{Quality: 85})
+	registerImageEncoder(tiffEncoder{})
+}
+
+// encoderForExt returns the registered encoder for ext (e.g. ".png"), or an
+// error naming the ext if nothing is registered for it.
+func encoderForExt(ext string) (ImageEncoder, error) {
+	enc, ok := imageEncoders[ext]
+	if !ok {
+		return nil, fmt.Errorf("no image encoder registered for %q", ext)
+	}
+	return enc, nil
+}
+
+type pngEncoder struct{}
+
+func (pngEncoder) Encode(w io.Writer, img image.Image) error { return png.Encode(w, img) }
+func (pngEncoder) Ext() string                               { return ".png" }
+func (pngEncoder) MIME() string                              { return "image/png" }
+
+type jpegEncoder struct{ Quality int }
+
+func (e jpegEncoder) Encode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: e.Quality})
+}
+func (jpegEncoder) Ext() string  { return ".jpg" }
+func (jpegEncoder) MIME() string { return "image/jpeg" }
+
+type gifEncoder struct{}
+
+func (gifEncoder) Encode(w io.Writer, img image.Image) error { return gif.Encode(w, img, nil) }
+func (gifEncoder) Ext() string                               { return ".gif" }
+func (gifEncoder) MIME() string                              { return "image/gif" }
+
+type webpEncoder struct{ Quality float32 }
+
+func (e webpEncoder) Encode(w io.Writer, img image.Image) error {
+	return webp.Encode(w, img, &webp.Options{Quality: e.Quality})
+}
+func (webpEncoder) Ext() string  { return ".webp" }
+func (webpEncoder) MIME() string { return "image/webp" }
+
+type tiffEncoder struct{}
+
+func (tiffEncoder) Encode(w io.Writer, img image.Image) error { return tiff.Encode(w, img, nil) }
+func (tiffEncoder) Ext() string                               { return ".tiff" }
+func (tiffEncoder) MIME() string                              { return "image/tiff" }
+
+// defaultImageFormats is the fallback weighted distribution used when a
+// profile doesn't set ImageFormats: every image is a JPEG, matching
+// mockgen's original behavior.
+var defaultImageFormats = []ImageFormatWeight{{Ext: ".jpg", Weight: 1}}
+
+// pickImageExt chooses an image extension for one file according to cfg's
+// weighted distribution (or defaultImageFormats if unset). It draws from
+// treeFaker, the single faker the tree-walk in generateInto uses for
+// construction-time decisions, so the choice stays reproducible under a
+// fixed seed no matter how parallel the later per-file writes are.
+func pickImageExt(treeFaker *gofakeit.Faker, cfg Config) string {
+	formats := cfg.ImageFormats
+	if len(formats) == 0 {
+		formats = defaultImageFormats
+	}
+
+	total := 0.0
+	for _, f := range formats {
+		total += f.Weight
+	}
+
+	pick := treeFaker.Float64Range(0, total)
+	cumulative := 0.0
+	for _, f := range formats {
+		cumulative += f.Weight
+		if pick < cumulative {
+			return f.Ext
+		}
+	}
+	return formats[len(formats)-1].Ext
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// TestEncoderForExtKnownFormats verifies every format the request asked for
+// (PNG, JPEG, GIF, WebP, TIFF) resolves to a registered encoder whose Ext
+// round-trips.
+func TestEncoderForExtKnownFormats(t *testing.T) {
+	for _, ext := range []string{".png", ".jpg", ".gif", ".webp", ".tiff"} {
+		enc, err := encoderForExt(ext)
+		if err != nil {
+			t.Fatalf("encoderForExt(%q): %v", ext, err)
+		}
+		if enc.Ext() != ext {
+			t.Errorf("encoderForExt(%q).Ext() = %q", ext, enc.Ext())
+		}
+	}
+}
+
+func TestEncoderForExtUnknown(t *testing.T) {
+	if _, err := encoderForExt(".bmp"); err == nil {
+		t.Error("expected an error for an unregistered extension")
+	}
+}
+
+// TestPickImageExtDefaultsToJPEG verifies a profile that leaves
+// ImageFormats unset keeps mockgen's original all-JPEG output.
+func TestPickImageExtDefaultsToJPEG(t *testing.T) {
+	cfg := Config{}
+	faker := gofakeit.New(1)
+	for i := 0; i < 10; i++ {
+		if got := pickImageExt(faker, cfg); got != ".jpg" {
+			t.Fatalf("pickImageExt() = %q, want .jpg", got)
+		}
+	}
+}
+
+// TestPickImageExtHonorsDistribution verifies a 100%-weighted single
+// format always produces that format.
+func TestPickImageExtHonorsDistribution(t *testing.T) {
+	cfg := Config{ImageFormats: []ImageFormatWeight{{Ext: ".png", Weight: 1}}}
+	faker := gofakeit.New(1)
+	for i := 0; i < 10; i++ {
+		if got := pickImageExt(faker, cfg); got != ".png" {
+			t.Fatalf("pickImageExt() = %q, want .png", got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// manifestFileName is written at the root of every generated corpus.
+const manifestFileName = "manifest.json"
+
+// ManifestEntry records everything downstream encryption/decryption tests
+// need to verify one generated file without re-reading the plaintext:
+// where it is, how big it is, what it claims to be, and a content hash
+// taken while it was written.
+type ManifestEntry struct {
+	Path string      `json:"path"` // relative to BaseDir, forward-slash separated
+	Kind string      `json:"kind"`
+	MIME string      `json:"mime"`
+	Size int64       `json:"size"`
+	Mode fs.FileMode `json:"mode"`
+	Hash string      `json:"hash"` // hex-encoded BLAKE2b-256, empty when not computed (e.g. sparse files)
+
+	// Target is set only for symlink entries (see edgecases.go): the
+	// link's raw target, relative or absolute exactly as written on disk.
+	Target string `json:"target,omitempty"`
+}
+
+// Manifest is the root object serialized to manifest.json.
+type Manifest struct {
+	Entries []ManifestEntry `json:"entries"`
+}
+
+// writeManifest serializes entries to manifest.json at the root of dir.
+func writeManifest(dir string, entries []ManifestEntry, mode fs.FileMode) error {
+	data, err := json.MarshalIndent(Manifest{Entries: entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFileName), data, mode)
+}
+
+// LoadManifest reads manifest.json from dir, the ground-truth index of
+// every file a GenerateProfile call produced. Tests use it to iterate
+// entries, encrypt/decrypt, and verify the round trip against Hash.
+func LoadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, manifestFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
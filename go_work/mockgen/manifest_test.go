@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// TestGenerateProfileManifestRoundTrip verifies manifest.json lists every
+// generated file with a hash that matches the file's actual content, so
+// downstream tests can trust it as a ground-truth oracle.
+func TestGenerateProfileManifestRoundTrip(t *testing.T) {
+	cfg := Config{
+		Name:       "test-manifest",
+		BaseDir:    t.TempDir(),
+		TextFiles:  2,
+		CsvFiles:   1,
+		ImageFiles: 1,
+		Seed:       7,
+	}
+
+	if err := GenerateProfile(cfg); err != nil {
+		t.Fatalf("GenerateProfile: %v", err)
+	}
+
+	manifest, err := LoadManifest(cfg.BaseDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+
+	if got, want := len(manifest.Entries), cfg.TextFiles+cfg.CsvFiles+cfg.ImageFiles; got != want {
+		t.Fatalf("len(manifest.Entries) = %d, want %d", got, want)
+	}
+
+	for _, entry := range manifest.Entries {
+		data, err := os.ReadFile(filepath.Join(cfg.BaseDir, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			t.Fatalf("reading %s: %v", entry.Path, err)
+		}
+		if got := int64(len(data)); got != entry.Size {
+			t.Errorf("%s: size = %d, manifest says %d", entry.Path, got, entry.Size)
+		}
+
+		sum := blake2b.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != entry.Hash {
+			t.Errorf("%s: hash = %s, manifest says %s", entry.Path, got, entry.Hash)
+		}
+	}
+}
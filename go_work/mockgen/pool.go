@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultConcurrency picks a worker pool size appropriate for the host:
+// all logical CPUs on servers, but capped on interactive desktop OSes so
+// mockgen doesn't dominate them the way syncthing caps its hasher pool.
+func defaultConcurrency() int {
+	n := runtime.NumCPU()
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		if half := n / 2; half > 0 {
+			n = half
+		}
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// fileKind identifies which writer a fileJob should call.
+type fileKind int
+
+const (
+	kindText fileKind = iota
+	kindCSV
+	kindImage
+)
+
+func (k fileKind) String() string {
+	switch k {
+	case kindText:
+		return "text"
+	case kindCSV:
+		return "csv"
+	case kindImage:
+		return "image"
+	default:
+		return "unknown"
+	}
+}
+
+// fileJob is one file mockgen needs to produce. seed is pre-assigned when
+// the job is queued (see generateInto), so that even though jobs run on a
+// worker pool in whatever order the scheduler picks, each file is generated
+// from its own deterministic RNG stream and the corpus comes out
+// byte-identical regardless of how parallel the run was.
+type fileJob struct {
+	path string
+	kind fileKind
+	seed int64
+}
+
+// run writes j's file and returns the ManifestEntry describing it, using a
+// faker seeded solely from j.seed so the bytes produced don't depend on
+// which worker or scheduling order ran the job.
+func (j fileJob) run(cfg Config) (ManifestEntry, error) {
+	faker := gofakeit.New(uint64(j.seed))
+
+	var (
+		size int64
+		hash string
+		mime string
+		err  error
+	)
+	switch j.kind {
+	case kindText:
+		mime = "text/plain"
+		size, hash, err = writeTextFile(j.path, faker.Paragraph(5, 10, 50, " "), cfg.FileMode)
+	case kindCSV:
+		mime = "text/csv"
+		size, hash, err = writeCSVFile(faker, j.path, 100, cfg.FileMode)
+	case kindImage:
+		var enc ImageEncoder
+		if enc, err = encoderForExt(filepath.Ext(j.path)); err == nil {
+			mime = enc.MIME()
+			size, hash, err = writeImageFile(faker, j.path, 640, 480, cfg.FileMode)
+		}
+	default:
+		err = fmt.Errorf("unknown file kind %d for %s", j.kind, j.path)
+	}
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	rel, err := filepath.Rel(cfg.BaseDir, j.path)
+	if err != nil {
+		rel = j.path
+	}
+	info, err := os.Stat(j.path)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	return ManifestEntry{
+		Path: filepath.ToSlash(rel),
+		Kind: j.kind.String(),
+		MIME: mime,
+		Size: size,
+		Mode: info.Mode(),
+		Hash: hash,
+	}, nil
+}
+
+// runJobs dispatches jobs onto a worker pool bounded by cfg.Concurrency (or
+// defaultConcurrency when unset), logging each completion and returning the
+// resulting manifest entries or the first error any job produced.
+func runJobs(jobs []fileJob, cfg Config) ([]ManifestEntry, error) {
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency()
+	}
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+
+	entries := make([]ManifestEntry, len(jobs))
+	var done atomic.Int64
+	total := len(jobs)
+
+	for i, job := range jobs {
+		i, job := i, job
+		g.Go(func() error {
+			entry, err := job.run(cfg)
+			if err != nil {
+				return fmt.Errorf("generating %s: %w", job.path, err)
+			}
+			entries[i] = entry
+			n := done.Add(1)
+			fmt.Printf("[%d/%d] wrote %s\n", n, total, job.path)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
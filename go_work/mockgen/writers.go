@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"golang.org/x/crypto/blake2b"
+)
+
+// writeHashed opens path with mode, lets write populate it, and returns the
+// number of bytes written along with their hex-encoded BLAKE2b-256 digest.
+// The hash is computed as the content streams out via io.MultiWriter, so
+// callers never need to re-read the file to populate a manifest entry.
+//
+// mode is applied twice: once via O_CREATE (which the kernel masks against
+// the process umask and silently strips setuid/setgid/sticky from on file
+// creation) and again via an explicit Chmod once the file exists, which is
+// the only way to make those special bits actually stick.
+func writeHashed(path string, mode os.FileMode, write func(io.Writer) error) (size int64, hash string, err error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return 0, "", err
+	}
+	counter := &countingWriter{}
+
+	if err := write(io.MultiWriter(file, hasher, counter)); err != nil {
+		return 0, "", err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		return 0, "", err
+	}
+
+	return counter.n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// countingWriter tallies bytes written through it; used alongside the hash
+// writer in an io.MultiWriter so size and hash come from the same pass.
+type countingWriter struct{ n int64 }
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// writeTextFile creates text file
+func writeTextFile(path string, content string, mode os.FileMode) (size int64, hash string, err error) {
+	return writeHashed(path, mode, func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	})
+}
+
+// csvTimestampAnchor is the fixed "today" the Timestamp column's date
+// range is computed from. Using time.Now() here would mean the same seed
+// produces different CSV bytes depending on what day mockgen runs, which
+// defeats -seed's whole point of byte-identical, reproducible corpora.
+var csvTimestampAnchor = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// writeCSVFile creates mock csv file, drawing its fake data from faker so
+// callers can give each file its own deterministic RNG stream.
+func writeCSVFile(faker *gofakeit.Faker, path string, rows int, mode os.FileMode) (size int64, hash string, err error) {
+	return writeHashed(path, mode, func(w io.Writer) error {
+		writer := csv.NewWriter(w)
+
+		if err := writer.Write([]string{"UserID", "Timestamp", "Amount", "Description"}); err != nil {
+			return err
+		}
+
+		for range rows {
+			record := []string{
+				faker.UUID(),
+				faker.DateRange(csvTimestampAnchor.AddDate(0, -6, 0), csvTimestampAnchor).Format("2006-01-02"),
+				fmt.Sprintf("%.2f", faker.Float64Range(1.0, 1000.0)),
+				faker.HackerPhrase(),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+	})
+}
+
+// writeImageFile creates an image file, picking its encoder by path's
+// extension from the imageEncoders registry (see images.go) and drawing
+// the pixel data from faker so callers can give each file its own
+// deterministic RNG stream.
+func writeImageFile(faker *gofakeit.Faker, path string, width, height int, mode os.FileMode) (size int64, hash string, err error) {
+	enc, err := encoderForExt(filepath.Ext(path))
+	if err != nil {
+		return 0, "", err
+	}
+
+	img := faker.Image(width, height)
+
+	return writeHashed(path, mode, func(w io.Writer) error {
+		return enc.Encode(w, img)
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// TestWriteCSVFileTimestampsAreAnchored verifies the Timestamp column is
+// derived from the fixed csvTimestampAnchor rather than time.Now(), so the
+// same seed produces byte-identical CSVs no matter what day mockgen runs.
+func TestWriteCSVFileTimestampsAreAnchored(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+	faker := gofakeit.New(42)
+
+	if _, _, err := writeCSVFile(faker, path, 20, 0600); err != nil {
+		t.Fatalf("writeCSVFile: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open csv: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	earliest := csvTimestampAnchor.AddDate(0, -6, 0)
+	for _, row := range rows[1:] { // skip header
+		ts, err := time.Parse("2006-01-02", row[1])
+		if err != nil {
+			t.Fatalf("parsing timestamp %q: %v", row[1], err)
+		}
+		if ts.Before(earliest) || ts.After(csvTimestampAnchor) {
+			t.Errorf("timestamp %s outside the anchored range [%s, %s]", row[1], earliest, csvTimestampAnchor)
+		}
+	}
+}